@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// String implements fmt.Stringer, rendering the level using the same label used in log lines.
+func (l LogLevel) String() string {
+	if label, ok := labels[l]; ok {
+		return label
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// ParseLevel parses a level name ("debug"/"d", "info"/"i", "warning"/"warn"/"w", or
+// "error"/"e", case-insensitively) into a LogLevel, returning an error for unrecognized values.
+func ParseLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(s) {
+	case "debug", "d":
+		return DEBUG, nil
+	case "info", "i":
+		return INFO, nil
+	case "warning", "warn", "w":
+		return WARNING, nil
+	case "error", "e":
+		return ERROR, nil
+	default:
+		return 0, fmt.Errorf("logger: unrecognized log level %q", s)
+	}
+}
+
+// MustParseLevel is like ParseLevel but panics if s is not a recognized level.
+func MustParseLevel(s string) LogLevel {
+	level, err := ParseLevel(s)
+	if err != nil {
+		panic(err)
+	}
+	return level
+}
+
+// ParseLevelDefault parses s into a LogLevel, returning fallback if s is not recognized.
+func ParseLevelDefault(s string, fallback LogLevel) LogLevel {
+	level, err := ParseLevel(s)
+	if err != nil {
+		return fallback
+	}
+	return level
+}
+
+// LevelFromEnv reads envVar and parses it into a LogLevel, returning fallback if the
+// variable is unset or holds an unrecognized value. This lets callers wire
+// `LOG_LEVEL=debug` straight into a Logger without the pointer-to-string gymnastics
+// that SetLogLevel requires.
+func LevelFromEnv(envVar string, fallback LogLevel) LogLevel {
+	value, ok := os.LookupEnv(envVar)
+	if !ok {
+		return fallback
+	}
+	return ParseLevelDefault(value, fallback)
+}
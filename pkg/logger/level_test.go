@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+// TestParseLevel verifies that every recognized spelling parses to the right LogLevel.
+func TestParseLevel(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected LogLevel
+	}{
+		{"debug", DEBUG},
+		{"DEBUG", DEBUG},
+		{"d", DEBUG},
+		{"info", INFO},
+		{"i", INFO},
+		{"warning", WARNING},
+		{"warn", WARNING},
+		{"w", WARNING},
+		{"error", ERROR},
+		{"e", ERROR},
+	}
+
+	for _, tc := range testCases {
+		level, err := ParseLevel(tc.input)
+		if err != nil {
+			t.Errorf("ParseLevel(%q) returned unexpected error: %v", tc.input, err)
+		}
+		if level != tc.expected {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tc.input, level, tc.expected)
+		}
+	}
+}
+
+// TestParseLevelUnrecognized verifies that ParseLevel errors instead of silently defaulting.
+func TestParseLevelUnrecognized(t *testing.T) {
+	if _, err := ParseLevel("nonsense"); err == nil {
+		t.Error("expected ParseLevel to return an error for an unrecognized level")
+	}
+}
+
+// TestMustParseLevelPanics verifies that MustParseLevel panics on an unrecognized level.
+func TestMustParseLevelPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustParseLevel to panic for an unrecognized level")
+		}
+	}()
+	MustParseLevel("nonsense")
+}
+
+// TestParseLevelDefault verifies the fallback behavior for unrecognized levels.
+func TestParseLevelDefault(t *testing.T) {
+	if level := ParseLevelDefault("info", ERROR); level != INFO {
+		t.Errorf("expected recognized level to parse, got %v", level)
+	}
+	if level := ParseLevelDefault("nonsense", WARNING); level != WARNING {
+		t.Errorf("expected unrecognized level to fall back to %v, got %v", WARNING, level)
+	}
+}
+
+// TestLevelFromEnv verifies that LevelFromEnv reads, parses, and falls back correctly.
+func TestLevelFromEnv(t *testing.T) {
+	const envVar = "LOGGER_TEST_LEVEL"
+
+	os.Unsetenv(envVar)
+	if level := LevelFromEnv(envVar, WARNING); level != WARNING {
+		t.Errorf("expected fallback %v for an unset env var, got %v", WARNING, level)
+	}
+
+	os.Setenv(envVar, "debug")
+	defer os.Unsetenv(envVar)
+	if level := LevelFromEnv(envVar, WARNING); level != DEBUG {
+		t.Errorf("expected %v from env var, got %v", DEBUG, level)
+	}
+}
+
+// TestLogLevelString verifies that LogLevel implements fmt.Stringer with the expected labels.
+func TestLogLevelString(t *testing.T) {
+	testCases := map[LogLevel]string{
+		DEBUG:   "DEBUG",
+		INFO:    "INFO",
+		WARNING: "WARNING",
+		ERROR:   "ERROR",
+	}
+	for level, expected := range testCases {
+		if got := level.String(); got != expected {
+			t.Errorf("LogLevel(%d).String() = %q, want %q", level, got, expected)
+		}
+	}
+
+	if got := LogLevel(99).String(); got == "" {
+		t.Error("expected a non-empty String() for an unrecognized LogLevel")
+	}
+}
+
+// TestSetLogLevelBackwardCompatibility verifies that SetLogLevel still defaults to ERROR for unrecognized values.
+func TestSetLogLevelBackwardCompatibility(t *testing.T) {
+	defer SetLevel(DEBUG)
+
+	unrecognized := "nonsense"
+	SetLogLevel(&unrecognized)
+	if logInstance.Level() != ERROR {
+		t.Errorf("expected SetLogLevel to default to ERROR, got %v", logInstance.Level())
+	}
+
+	warn := "warn"
+	SetLogLevel(&warn)
+	if logInstance.Level() != WARNING {
+		t.Errorf("expected SetLogLevel(\"warn\") to set WARNING, got %v", logInstance.Level())
+	}
+}
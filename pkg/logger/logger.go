@@ -2,9 +2,9 @@ package logger
 
 import (
 	"fmt"
-	"log"
+	"io"
 	"os"
-	"strings"
+	"sync/atomic"
 )
 
 type LogLevel int
@@ -17,6 +17,16 @@ const (
 	ERROR
 )
 
+// Format selects how a Logger renders its records.
+type Format int
+
+const (
+	// FormatText renders colored, human-readable lines via the standard log package.
+	FormatText Format = iota
+	// FormatJSON renders structured records via log/slog, suitable for machine parsing.
+	FormatJSON
+)
+
 // Colors for terminal output (optional)
 const (
 	Reset  = "\033[0m"
@@ -34,10 +44,27 @@ var levelColors = map[LogLevel]string{
 	ERROR:   Red,
 }
 
-// Logger struct with level filtering
+// labels holds the text label printed for each level.
+var labels = map[LogLevel]string{
+	DEBUG:   "DEBUG",
+	INFO:    "INFO",
+	WARNING: "WARNING",
+	ERROR:   "ERROR",
+}
+
+// ExitFunc is called with a process exit code by ErrorF and FatalF. Tests can
+// substitute it for a function that records the code instead of terminating the process.
+type ExitFunc func(int)
+
+// Logger struct with level filtering. The level lives behind an atomic.Int32 so that
+// SetLevel and the logging methods can be called concurrently from multiple goroutines
+// without a data race. It's stored as a pointer so that loggers derived via With share
+// their parent's level rather than freezing a stale copy.
 type Logger struct {
-	level  LogLevel
-	logger *log.Logger // Embed the standard logger
+	level    *atomic.Int32
+	sinks    *MultiSink
+	keyvals  []interface{}
+	ExitFunc ExitFunc
 }
 
 // Global package-level logger
@@ -48,91 +75,170 @@ func init() {
 	logInstance = NewLogger(DEBUG)
 }
 
-// NewLogger initializes a logger with a specific log level
+// NewLogger initializes a colored, text-mode logger with a specific log level
 func NewLogger(level LogLevel) *Logger {
-	stdLogger := log.New(os.Stdout, "", log.Ldate|log.Ltime|log.Lshortfile)
-	return &Logger{level: level, logger: stdLogger}
+	return NewLoggerWithFormat(level, FormatText, os.Stdout)
+}
+
+// NewLoggerWithFormat initializes a logger with a specific level, output format, and writer.
+// FormatText keeps the current colored, human-readable output; FormatJSON emits structured
+// records (time, level, caller, msg, and any attached key/value pairs) via log/slog.
+func NewLoggerWithFormat(level LogLevel, format Format, out io.Writer) *Logger {
+	l := &Logger{level: &atomic.Int32{}, sinks: &MultiSink{}, ExitFunc: os.Exit}
+	l.level.Store(int32(level))
+
+	var sink Sink
+	switch format {
+	case FormatJSON:
+		sink = newJSONSink(out)
+	default:
+		sink = newTextSink(out)
+	}
+	l.sinks.AddSink(sink, DEBUG)
+
+	return l
+}
+
+// AddSink registers an additional sink that receives every record at or above minLevel,
+// fanning each log call out alongside the logger's default sink. This lets a single
+// Logger feed, e.g., a colored stdout sink at INFO and a JSON file sink at DEBUG at once.
+func (l *Logger) AddSink(sink Sink, minLevel LogLevel) {
+	l.sinks.AddSink(sink, minLevel)
+}
+
+// SetLevel dynamically sets the logger's level. Safe to call concurrently with
+// logging calls on the same Logger.
+func (l *Logger) SetLevel(level LogLevel) {
+	l.level.Store(int32(level))
 }
 
-// SetLevel dynamically sets the logging level
+// Level returns the logger's current level. Safe to call concurrently with SetLevel.
+func (l *Logger) Level() LogLevel {
+	return LogLevel(l.level.Load())
+}
+
+// SetLevel dynamically sets the package-level logger's level.
 func SetLevel(level LogLevel) {
-	logInstance.level = level
+	logInstance.SetLevel(level)
+}
+
+// SetExitFunc overrides the function that ErrorF and FatalF call to terminate the
+// process, letting tests substitute a function that records the exit code instead
+// of calling os.Exit.
+func SetExitFunc(exitFunc ExitFunc) {
+	logInstance.ExitFunc = exitFunc
 }
 
+// SetLogLevel sets the package-level log level from a string, defaulting to ERROR for
+// unrecognized values. Kept for backward compatibility; prefer ParseLevel (or
+// ParseLevelDefault for a custom fallback) combined with SetLevel in new code.
 func SetLogLevel(level *string) {
-	var logLevel LogLevel
-
-	switch strings.ToLower(*level) {
-	case "info", "i":
-		logLevel = INFO
-	case "debug", "d":
-		logLevel = DEBUG
-	case "warning", "warn", "w":
-		logLevel = WARNING
-	default:
-		logLevel = ERROR
-	}
+	SetLevel(ParseLevelDefault(*level, ERROR))
+}
 
-	SetLevel(logLevel)
+// With returns a copy of the Logger carrying the given key/value pairs, modeled on
+// go-kit's log.With. Those pairs are attached to every subsequent record: the text
+// sink appends them in logfmt style (key=value), and the JSON sink emits them as
+// structured fields. This lets callers stamp request IDs, user IDs, and similar
+// correlation data onto all of their log calls, including across goroutines when
+// threaded through a context.Context. The returned Logger shares its parent's level,
+// so SetLevel on either one affects both.
+func (l *Logger) With(keyvals ...interface{}) *Logger {
+	nl := *l
+	nl.keyvals = append(append([]interface{}{}, l.keyvals...), keyvals...)
+	return &nl
 }
 
-// logMessage formats and logs messages with levels and variadic arguments
-func (l *Logger) logMessage(level LogLevel, label string, color string, args ...interface{}) {
-	if level < l.level {
-		return // Do not log messages below the current level
+// formatMessage renders the variadic Debug/Info/Warning/Error arguments into a single string.
+func formatMessage(args ...interface{}) string {
+	if len(args) == 0 {
+		return ""
 	}
 
-	var message string
-
-	if len(args) == 0 {
-		message = ""
-	} else if len(args) == 1 {
+	if len(args) == 1 {
 		switch v := args[0].(type) {
 		case error:
-			message = v.Error()
+			return v.Error()
 		case string:
-			message = v
+			return v
 		default:
-			message = fmt.Sprintf("%+v", v)
-		}
-	} else {
-		// Assume the first argument is a format string
-		format, ok := args[0].(string)
-		if !ok {
-			// If the first argument isn't a string, format the entire arguments
-			message = fmt.Sprintf("%+v", args)
-		} else {
-			message = fmt.Sprintf(format, args[1:]...)
+			return fmt.Sprintf("%+v", v)
 		}
 	}
 
-	// Use log.Output with calldepth = 3 to go up to the caller of Debug/Info/Warning/Error
-	calldepth := 3
-	_ = l.logger.Output(calldepth, fmt.Sprintf("%s[%s] %s%s", color, label, message, Reset))
+	// Assume the first argument is a format string
+	format, ok := args[0].(string)
+	if !ok {
+		// If the first argument isn't a string, format the entire arguments
+		return fmt.Sprintf("%+v", args)
+	}
+	return fmt.Sprintf(format, args[1:]...)
+}
+
+// logMessage formats and fans the record out to every registered sink
+func (l *Logger) logMessage(level LogLevel, label string, args ...interface{}) {
+	if level < l.Level() {
+		return // Do not log messages below the current level
+	}
+
+	message := formatMessage(args...)
+	l.sinks.Write(level, label, message, l.keyvals...)
 }
 
 // Debug logs a debug message
-func Debug(args ...interface{}) {
-	logInstance.logMessage(DEBUG, "DEBUG", levelColors[DEBUG], args...)
+func (l *Logger) Debug(args ...interface{}) {
+	l.logMessage(DEBUG, labels[DEBUG], args...)
 }
 
 // Info logs an info message
-func Info(args ...interface{}) {
-	logInstance.logMessage(INFO, "INFO", levelColors[INFO], args...)
+func (l *Logger) Info(args ...interface{}) {
+	l.logMessage(INFO, labels[INFO], args...)
 }
 
 // Warning logs a warning message
-func Warning(args ...interface{}) {
-	logInstance.logMessage(WARNING, "WARNING", levelColors[WARNING], args...)
+func (l *Logger) Warning(args ...interface{}) {
+	l.logMessage(WARNING, labels[WARNING], args...)
 }
 
 // Error logs an error message
+func (l *Logger) Error(args ...interface{}) {
+	l.logMessage(ERROR, labels[ERROR], args...)
+}
+
+// Debug logs a debug message on the package-level logger. Calls logMessage directly,
+// rather than through the Debug method, so its call depth matches (*Logger).Debug and
+// the text sink reports the true caller instead of this wrapper.
+func Debug(args ...interface{}) {
+	logInstance.logMessage(DEBUG, labels[DEBUG], args...)
+}
+
+// Info logs an info message on the package-level logger. See Debug for why this calls
+// logMessage directly instead of (*Logger).Info.
+func Info(args ...interface{}) {
+	logInstance.logMessage(INFO, labels[INFO], args...)
+}
+
+// Warning logs a warning message on the package-level logger. See Debug for why this
+// calls logMessage directly instead of (*Logger).Warning.
+func Warning(args ...interface{}) {
+	logInstance.logMessage(WARNING, labels[WARNING], args...)
+}
+
+// Error logs an error message on the package-level logger. See Debug for why this calls
+// logMessage directly instead of (*Logger).Error.
 func Error(args ...interface{}) {
-	logInstance.logMessage(ERROR, "ERROR", levelColors[ERROR], args...)
+	logInstance.logMessage(ERROR, labels[ERROR], args...)
 }
 
-// ErrorF logs an error message and exits the app
+// ErrorF logs an error message and exits the app with status 1
 func ErrorF(args ...interface{}) {
 	Error(args...)
-	os.Exit(1)
+	logInstance.ExitFunc(1)
+}
+
+// FatalF logs an error message and exits the app with the given exit code, for callers
+// that want a non-1 exit status (mirroring the Errx(exit int, ...) pattern).
+func FatalF(exitCode int, args ...interface{}) {
+	Error(args...)
+	logInstance.ExitFunc(exitCode)
 }
@@ -0,0 +1,149 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Sink receives fully-formatted log records. Implementations decide how and where
+// a record is persisted (stdout, a file, syslog, an HTTP endpoint, ...).
+type Sink interface {
+	Write(level LogLevel, label, message string, kv ...interface{})
+}
+
+// slogLevels maps our LogLevel to the equivalent slog.Level.
+var slogLevels = map[LogLevel]slog.Level{
+	DEBUG:   slog.LevelDebug,
+	INFO:    slog.LevelInfo,
+	WARNING: slog.LevelWarn,
+	ERROR:   slog.LevelError,
+}
+
+// textSink renders colored, human-readable lines via the standard log package.
+type textSink struct {
+	logger *log.Logger
+}
+
+func newTextSink(out io.Writer) *textSink {
+	return &textSink{logger: log.New(out, "", log.Ldate|log.Ltime|log.Lshortfile)}
+}
+
+func (s *textSink) Write(level LogLevel, label, message string, kv ...interface{}) {
+	// calldepth climbs: Output -> Write -> filteredSink.write -> MultiSink.Write -> logMessage -> Debug/Info/...
+	calldepth := 6
+	line := fmt.Sprintf("%s[%s] %s%s", levelColors[level], label, message, Reset)
+	if logfmt := formatKeyvals(kv...); logfmt != "" {
+		line = line + " " + logfmt
+	}
+	_ = s.logger.Output(calldepth, line)
+}
+
+// formatKeyvals renders key/value pairs attached via Logger.With in logfmt style
+// (key1=value1 key2=value2), the same pairs the JSON sink emits as structured fields.
+func formatKeyvals(kv ...interface{}) string {
+	if len(kv) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i := 0; i+1 < len(kv); i += 2 {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%v=%v", kv[i], kv[i+1])
+	}
+	return b.String()
+}
+
+// jsonSink renders structured records via log/slog, suitable for machine parsing.
+type jsonSink struct {
+	handler slog.Handler
+}
+
+func newJSONSink(out io.Writer) *jsonSink {
+	// Level is pinned to the lowest level so slog never filters on our behalf; the
+	// Logger's own level field and each sink's filteredSink wrapper are the sole gates.
+	// AddSource is left off: slog's own source capture would name the field "source" and
+	// point at this file, since it attributes the record to its immediate caller. We build
+	// the record by hand below so the "caller" field names the real call site instead.
+	handler := slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return &jsonSink{handler: handler}
+}
+
+func (s *jsonSink) Write(level LogLevel, label, message string, kv ...interface{}) {
+	// callersSkip climbs past runtime.Callers itself, Write, filteredSink.write,
+	// MultiSink.Write, logMessage, and Debug/Info/... to reach the real call site,
+	// mirroring the stack textSink's calldepth walks via log.Logger.Output.
+	const callersSkip = 6
+	var pcs [1]uintptr
+	runtime.Callers(callersSkip, pcs[:])
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+
+	record := slog.NewRecord(time.Now(), slogLevels[level], message, 0)
+	if frame.PC != 0 {
+		record.AddAttrs(slog.String("caller", fmt.Sprintf("%s:%d", frame.File, frame.Line)))
+	}
+	record.Add(dropReservedKey(kv, "caller")...)
+	_ = s.handler.Handle(context.Background(), record)
+}
+
+// dropReservedKey filters out any key/value pair in kv whose key matches reserved, so a
+// caller-supplied value (e.g. attached via Logger.With) can't collide with a field the
+// sink sets itself, which would otherwise emit the same JSON key twice.
+func dropReservedKey(kv []interface{}, reserved string) []interface{} {
+	filtered := make([]interface{}, 0, len(kv))
+	for i := 0; i+1 < len(kv); i += 2 {
+		if key, ok := kv[i].(string); ok && key == reserved {
+			continue
+		}
+		filtered = append(filtered, kv[i], kv[i+1])
+	}
+	return filtered
+}
+
+// filteredSink wraps a Sink so it only receives records at or above minLevel.
+type filteredSink struct {
+	sink     Sink
+	minLevel LogLevel
+}
+
+// write forwards the record to the wrapped sink unless it falls below minLevel. A
+// panicking sink (e.g. a broken network write) is recovered so it cannot block the
+// other sinks registered on the same MultiSink.
+func (f filteredSink) write(level LogLevel, label, message string, kv ...interface{}) {
+	if level < f.minLevel {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "logger: sink write failed: %v\n", r)
+		}
+	}()
+	f.sink.Write(level, label, message, kv...)
+}
+
+// MultiSink fans a single log record out to every registered sink, each filtered at
+// its own minimum level. A colored stdout sink at INFO, a JSON file sink at DEBUG,
+// and a syslog/HTTP sink at ERROR can all be registered on the same MultiSink.
+type MultiSink struct {
+	sinks []filteredSink
+}
+
+// AddSink registers sink so it receives every record at or above minLevel.
+func (m *MultiSink) AddSink(sink Sink, minLevel LogLevel) {
+	m.sinks = append(m.sinks, filteredSink{sink: sink, minLevel: minLevel})
+}
+
+// Write fans the record out to every registered sink.
+func (m *MultiSink) Write(level LogLevel, label, message string, kv ...interface{}) {
+	for _, fs := range m.sinks {
+		fs.write(level, label, message, kv...)
+	}
+}
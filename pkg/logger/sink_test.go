@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// recordingSink records every record it receives, for assertions in tests.
+type recordingSink struct {
+	levels []LogLevel
+}
+
+func (s *recordingSink) Write(level LogLevel, label, message string, kv ...interface{}) {
+	s.levels = append(s.levels, level)
+}
+
+// failingSink panics on every write, to exercise MultiSink's error isolation.
+type failingSink struct{}
+
+func (failingSink) Write(level LogLevel, label, message string, kv ...interface{}) {
+	panic("simulated sink failure")
+}
+
+// TestMultiSinkPerSinkLevelFilter verifies that each sink only receives records at or above its own minLevel.
+func TestMultiSinkPerSinkLevelFilter(t *testing.T) {
+	debugSink := &recordingSink{}
+	errorSink := &recordingSink{}
+
+	multi := &MultiSink{}
+	multi.AddSink(debugSink, DEBUG)
+	multi.AddSink(errorSink, ERROR)
+
+	multi.Write(INFO, labels[INFO], "info message")
+	multi.Write(ERROR, labels[ERROR], "error message")
+
+	if len(debugSink.levels) != 2 {
+		t.Errorf("expected the DEBUG-filtered sink to receive 2 records, got %d", len(debugSink.levels))
+	}
+	if len(errorSink.levels) != 1 || errorSink.levels[0] != ERROR {
+		t.Errorf("expected the ERROR-filtered sink to receive only the ERROR record, got %v", errorSink.levels)
+	}
+}
+
+// TestMultiSinkIsolatesFailingSink verifies that a panicking sink doesn't prevent other sinks from being written to.
+func TestMultiSinkIsolatesFailingSink(t *testing.T) {
+	healthySink := &recordingSink{}
+
+	multi := &MultiSink{}
+	multi.AddSink(failingSink{}, DEBUG)
+	multi.AddSink(healthySink, DEBUG)
+
+	multi.Write(ERROR, labels[ERROR], "boom")
+
+	if len(healthySink.levels) != 1 {
+		t.Errorf("expected the healthy sink to still receive the record, got %v", healthySink.levels)
+	}
+}
+
+// TestLoggerAddSinkFansOut verifies that a Logger fans a single call out to its default sink and any added sinks.
+func TestLoggerAddSinkFansOut(t *testing.T) {
+	var jsonBuf bytes.Buffer
+	l := NewLoggerWithFormat(DEBUG, FormatText, &bytes.Buffer{})
+	l.AddSink(newJSONSink(&jsonBuf), INFO)
+
+	l.logMessage(INFO, labels[INFO], "fan out")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(jsonBuf.Bytes(), &record); err != nil {
+		t.Fatalf("expected the added JSON sink to receive the record, got error %v for %q", err, jsonBuf.String())
+	}
+	if record["msg"] != "fan out" {
+		t.Errorf("expected msg %q, got %v", "fan out", record["msg"])
+	}
+}
+
+// TestLoggerWithKeyvalsFansOutToAllSinks verifies that key/value pairs attached via
+// With reach every registered sink, each rendered in that sink's own style.
+func TestLoggerWithKeyvalsFansOutToAllSinks(t *testing.T) {
+	var jsonBuf, textBuf bytes.Buffer
+	l := NewLoggerWithFormat(DEBUG, FormatText, &textBuf)
+	l.AddSink(newJSONSink(&jsonBuf), DEBUG)
+
+	l.With("request_id", "abc123").logMessage(INFO, labels[INFO], "handled request")
+
+	if !bytes.Contains(textBuf.Bytes(), []byte("request_id=abc123")) {
+		t.Errorf("expected the text sink to render logfmt keyvals, got %q", textBuf.String())
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(jsonBuf.Bytes(), &record); err != nil {
+		t.Fatalf("expected valid JSON output, got error %v for %q", err, jsonBuf.String())
+	}
+	if record["request_id"] != "abc123" {
+		t.Errorf("expected the JSON sink to render request_id as a field, got %v", record)
+	}
+}
+
+func BenchmarkLoggerUnfiltered(b *testing.B) {
+	l := NewLoggerWithFormat(DEBUG, FormatText, &bytes.Buffer{})
+	err := errors.New("benchmark error")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.logMessage(DEBUG, labels[DEBUG], err)
+	}
+}
+
+func BenchmarkLoggerFiltered(b *testing.B) {
+	l := NewLoggerWithFormat(ERROR, FormatText, &bytes.Buffer{})
+	err := errors.New("benchmark error")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.logMessage(DEBUG, labels[DEBUG], err)
+	}
+}